@@ -0,0 +1,120 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+// Package admin implements the `admin` JSON-RPC namespace. It is only ever
+// registered on the authenticated transport: every method here reveals or
+// controls node-operational details that shouldn't be exposed publicly.
+package admin
+
+import (
+	"context"
+	"sync"
+
+	"cosmossdk.io/log"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+)
+
+// RPCServer is the subset of *http.Server (or an equivalent listener-backed
+// server) the admin namespace can start/stop. Kept as an interface so this
+// package doesn't need to import the server package that owns the concrete
+// type, avoiding an import cycle with server -> rpc -> admin.
+type RPCServer interface {
+	Start() error
+	Stop() error
+}
+
+// PublicAdminAPI offers the admin_ prefixed methods for the RPC.
+type PublicAdminAPI struct {
+	logger log.Logger
+	client rpcclient.Client
+
+	mu     sync.Mutex
+	public RPCServer
+}
+
+// NewPublicAdminAPI creates a new admin API instance. client is used to read
+// peer/node info from CometBFT; public is the node's public JSON-RPC server,
+// controlled by StartRPC/StopRPC.
+func NewPublicAdminAPI(logger log.Logger, client rpcclient.Client, public RPCServer) *PublicAdminAPI {
+	return &PublicAdminAPI{logger: logger, client: client, public: public}
+}
+
+// PeerInfo summarizes one connected peer, mirroring the fields geth's
+// admin_peers surfaces that make sense on top of a CometBFT network layer.
+type PeerInfo struct {
+	ID         string `json:"id"`
+	Moniker    string `json:"moniker"`
+	RemoteAddr string `json:"remoteAddr"`
+	IsOutbound bool   `json:"isOutbound"`
+}
+
+// Peers returns the list of peers the consensus node is currently connected
+// to, like go-ethereum's admin_peers.
+func (api *PublicAdminAPI) Peers(ctx context.Context) ([]PeerInfo, error) {
+	netInfo, err := api.client.NetInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make([]PeerInfo, 0, len(netInfo.Peers))
+	for _, p := range netInfo.Peers {
+		peers = append(peers, PeerInfo{
+			ID:         string(p.ID),
+			Moniker:    p.NodeInfo.Moniker,
+			RemoteAddr: p.RemoteIP,
+			IsOutbound: p.IsOutbound,
+		})
+	}
+	return peers, nil
+}
+
+// NodeInfo returns general information about the node, like go-ethereum's
+// admin_nodeInfo.
+func (api *PublicAdminAPI) NodeInfo(ctx context.Context) (interface{}, error) {
+	status, err := api.client.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return status.NodeInfo, nil
+}
+
+// StartRPC (re)starts the node's public JSON-RPC HTTP server, like
+// go-ethereum's admin_startRPC. The authenticated endpoint this method is
+// served from is unaffected.
+func (api *PublicAdminAPI) StartRPC() (bool, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if err := api.public.Start(); err != nil {
+		api.logger.Error("admin_startRPC failed", "error", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// StopRPC stops the node's public JSON-RPC HTTP server, like go-ethereum's
+// admin_stopRPC. The authenticated endpoint this method is served from is
+// unaffected.
+func (api *PublicAdminAPI) StopRPC() (bool, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if err := api.public.Stop(); err != nil {
+		api.logger.Error("admin_stopRPC failed", "error", err)
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+// Package txpool implements the `txpool` JSON-RPC namespace. It is a
+// read-only view over the same pending-transaction pool the `eth` namespace's
+// `newPendingTransactions` subscription is fed from, so it always reflects
+// what the node has actually seen in CheckTx rather than re-deriving mempool
+// state from a separate source.
+package txpool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+)
+
+// pendingPool is the subset of *stream.RPCStream's pending pool the txpool
+// API needs; kept as an interface so this package doesn't import stream
+// (which would be a cyclic-looking dependency for a leaf namespace package).
+type pendingPool interface {
+	Status() (pending, queued uint64)
+	Content() map[common.Address]map[uint64]*rpctypes.RPCTransaction
+}
+
+// PublicTxPoolAPI offers and the txpool_ prefixed methods for the RPC. It
+// only ever reports the "pending" bucket: nonce-gap ("queued") detection is
+// not implemented, matching the pendingPool it reads from.
+type PublicTxPoolAPI struct {
+	pool pendingPool
+}
+
+// NewPublicTxPoolAPI creates a new txpool API instance backed by pool.
+func NewPublicTxPoolAPI(pool pendingPool) *PublicTxPoolAPI {
+	return &PublicTxPoolAPI{pool: pool}
+}
+
+// TxPoolStatusResult is the result type for txpool_status.
+type TxPoolStatusResult struct {
+	Pending hexutil.Uint `json:"pending"`
+	Queued  hexutil.Uint `json:"queued"`
+}
+
+// Status returns the number of pending and queued transactions.
+func (api *PublicTxPoolAPI) Status() TxPoolStatusResult {
+	pending, queued := api.pool.Status()
+	return TxPoolStatusResult{
+		Pending: hexutil.Uint(pending),
+		Queued:  hexutil.Uint(queued),
+	}
+}
+
+// content formats the pending pool as nonce(string)->tx maps per sender, the
+// shape shared by txpool_content and txpool_contentFrom.
+func content(byAddr map[common.Address]map[uint64]*rpctypes.RPCTransaction) map[string]map[string]*rpctypes.RPCTransaction {
+	out := make(map[string]map[string]*rpctypes.RPCTransaction, len(byAddr))
+	for addr, byNonce := range byAddr {
+		txs := make(map[string]*rpctypes.RPCTransaction, len(byNonce))
+		for nonce, tx := range byNonce {
+			txs[hexutil.Uint64(nonce).String()] = tx
+		}
+		out[addr.Hex()] = txs
+	}
+	return out
+}
+
+// Content returns the pending and queued transactions, grouped by sender
+// address and nonce, like go-ethereum's txpool_content.
+func (api *PublicTxPoolAPI) Content() map[string]map[string]map[string]*rpctypes.RPCTransaction {
+	return map[string]map[string]map[string]*rpctypes.RPCTransaction{
+		"pending": content(api.pool.Content()),
+		"queued":  {},
+	}
+}
+
+// ContentFrom returns the pending and queued transactions of one sender
+// address, like go-ethereum's txpool_contentFrom.
+func (api *PublicTxPoolAPI) ContentFrom(address common.Address) map[string]map[string]*rpctypes.RPCTransaction {
+	byNonce := api.pool.Content()[address]
+
+	txs := make(map[string]*rpctypes.RPCTransaction, len(byNonce))
+	for nonce, tx := range byNonce {
+		txs[hexutil.Uint64(nonce).String()] = tx
+	}
+
+	return map[string]map[string]*rpctypes.RPCTransaction{
+		"pending": txs,
+		"queued":  {},
+	}
+}
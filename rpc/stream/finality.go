@@ -0,0 +1,126 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package stream
+
+const (
+	safeHeaderStreamSegmentSize      = 128
+	safeHeaderStreamCapacity         = 128 * 32
+	finalizedHeaderStreamSegmentSize = 128
+	finalizedHeaderStreamCapacity    = 128 * 32
+
+	// DefaultFinalizedDepth is how many blocks behind latest a block must be
+	// before it's considered "finalized". A depth below 1 is treated as 1, so
+	// "finalized" never resolves to something newer than "safe" (itself one
+	// block behind latest): see observe. CometBFT blocks are final as soon as
+	// they're committed, so the default 0, which lines "finalized" up with
+	// "safe", is correct for the common case; it only needs to grow for
+	// chains that choose to treat finality more conservatively than
+	// CometBFT's own guarantee.
+	DefaultFinalizedDepth = 0
+)
+
+// finalityTracker turns the single stream of per-block headers into the
+// "safe" and "finalized" aliases EIP-1898 tooling expects:
+//
+//   - "safe" trails by one block: a block's LastCommit - the proof that more
+//     than 2/3 of voting power signed it - is only embedded in the block that
+//     follows it, so the previous block is the most recent one we can point
+//     to as actually having that proof available.
+//   - "finalized" trails latest by finalizedDepth blocks, but never by fewer
+//     than "safe"'s one block (so the default of 0, since CometBFT gives
+//     instant finality once a block is committed, lines it up with "safe"):
+//     finalized <= safe <= latest always holds.
+type finalityTracker struct {
+	finalizedDepth uint64
+
+	// recent holds the last observed headers keyed by height, so a header can
+	// still be looked up once it's old enough to satisfy a policy.
+	recent     map[int64]RPCHeader
+	lastHeight int64
+}
+
+func newFinalityTracker(finalizedDepth uint64) *finalityTracker {
+	return &finalityTracker{
+		finalizedDepth: finalizedDepth,
+		recent:         make(map[int64]RPCHeader),
+	}
+}
+
+// observe records a newly seen header at height and returns the safe and
+// finalized headers it newly unlocks, if any. Reorgs shouldn't happen at the
+// CometBFT level, but could in theory occur across a hard fork that replays
+// history; observe detects that case by checking that height extends the
+// chain it has seen so far and that the new header's ParentHash matches the
+// previously observed header's Hash at height-1. Both fields live in
+// CometBFT's own hash space (RPCHeader.ParentHash is
+// data.Block.Header.LastBlockID.Hash, RPCHeader.Hash is the block's own
+// Header.Hash()), so, unlike comparing against EthHeader.ParentHash, a
+// normal sequential block is guaranteed to match here. When the invariant
+// breaks, ok is false and the tracker resets: any previously emitted
+// "finalized"/"safe" header is no longer trustworthy, so callers should stop
+// relying on it until the tracker has rebuilt enough history to vouch for a
+// new one.
+func (t *finalityTracker) observe(header RPCHeader, height int64) (safe, finalized *RPCHeader, ok bool) {
+	if t.lastHeight != 0 {
+		if height != t.lastHeight+1 {
+			t.reset()
+			return nil, nil, false
+		}
+		if prev, found := t.recent[height-1]; found && prev.Hash != header.ParentHash {
+			t.reset()
+			return nil, nil, false
+		}
+	}
+
+	t.recent[height] = header
+	t.lastHeight = height
+	t.prune(height)
+
+	if h, found := t.recent[height-1]; found {
+		h := h
+		safe = &h
+	}
+	// finalized's offset from height is never allowed to be smaller than
+	// safe's own 1-block offset, so finalized can never resolve to something
+	// newer than safe - in particular, the finalizedDepth=0 default (CometBFT
+	// instant finality) lines finalized up with safe rather than with latest.
+	depth := int64(t.finalizedDepth)
+	if depth < 1 {
+		depth = 1
+	}
+	if height-depth >= 0 {
+		if h, found := t.recent[height-depth]; found {
+			h := h
+			finalized = &h
+		}
+	}
+	return safe, finalized, true
+}
+
+// prune drops headers too old to ever be looked up again.
+func (t *finalityTracker) prune(height int64) {
+	keep := int64(t.finalizedDepth) + 2
+	for h := range t.recent {
+		if height-h > keep {
+			delete(t.recent, h)
+		}
+	}
+}
+
+func (t *finalityTracker) reset() {
+	t.recent = make(map[int64]RPCHeader)
+	t.lastHeight = 0
+}
@@ -0,0 +1,63 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// header builds an RPCHeader for tests. parent is the CometBFT-space
+// ParentHash finalityTracker actually keys off; EthHeader.ParentHash is always
+// set to an unrelated, deliberately mismatched value to guard against the
+// tracker accidentally reading the wrong field.
+func header(hash, parent common.Hash) RPCHeader {
+	return RPCHeader{
+		EthHeader:  &ethtypes.Header{ParentHash: common.BytesToHash([]byte("not-the-same-hash-space"))},
+		Hash:       hash,
+		ParentHash: parent,
+	}
+}
+
+func TestFinalityTrackerSafeAndFinalized(t *testing.T) {
+	tracker := newFinalityTracker(2)
+
+	h1 := header(common.BytesToHash([]byte{1}), common.Hash{})
+	h2 := header(common.BytesToHash([]byte{2}), h1.Hash)
+	h3 := header(common.BytesToHash([]byte{3}), h2.Hash)
+
+	if safe, finalized, ok := tracker.observe(h1, 1); !ok || safe != nil || finalized != nil {
+		t.Fatalf("block 1 should unlock nothing yet, got safe=%v finalized=%v ok=%v", safe, finalized, ok)
+	}
+	if safe, finalized, ok := tracker.observe(h2, 2); !ok || safe == nil || safe.Hash != h1.Hash || finalized != nil {
+		t.Fatalf("block 2 should unlock safe=block1, got safe=%v finalized=%v ok=%v", safe, finalized, ok)
+	}
+	if safe, finalized, ok := tracker.observe(h3, 3); !ok || safe == nil || safe.Hash != h2.Hash || finalized == nil || finalized.Hash != h1.Hash {
+		t.Fatalf("block 3 should unlock safe=block2 finalized=block1, got safe=%v finalized=%v ok=%v", safe, finalized, ok)
+	}
+}
+
+func TestFinalityTrackerResetsOnReorg(t *testing.T) {
+	tracker := newFinalityTracker(0)
+
+	h1 := header(common.BytesToHash([]byte{1}), common.Hash{})
+	h2 := header(common.BytesToHash([]byte{2}), h1.Hash)
+	if _, _, ok := tracker.observe(h1, 1); !ok {
+		t.Fatal("expected block 1 to be accepted")
+	}
+	if _, _, ok := tracker.observe(h2, 2); !ok {
+		t.Fatal("expected block 2 to be accepted")
+	}
+
+	// a "reorg": a new block 3 whose parent hash doesn't match the observed
+	// block 2, as if the chain had been rewritten underneath us.
+	forked := header(common.BytesToHash([]byte{0xff}), common.BytesToHash([]byte{0xee}))
+	safe, finalized, ok := tracker.observe(forked, 3)
+	if ok || safe != nil || finalized != nil {
+		t.Fatalf("expected reorg to be detected and invalidate the tracker, got safe=%v finalized=%v ok=%v", safe, finalized, ok)
+	}
+
+	if tracker.lastHeight != 0 || len(tracker.recent) != 0 {
+		t.Fatal("expected tracker to reset its history after detecting a reorg")
+	}
+}
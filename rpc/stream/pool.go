@@ -0,0 +1,95 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+)
+
+// senderNonceKey identifies a pending transaction by the pair that uniquely
+// slots it into an account's nonce sequence, mirroring how go-ethereum's
+// txpool keys its pending/queued maps.
+type senderNonceKey struct {
+	sender common.Address
+	nonce  uint64
+}
+
+// pendingPool is an in-memory set of transactions that have been seen in
+// CheckTx but not yet included in a block, keyed by sender+nonce. Entries are
+// added as pending transactions are observed and removed when RPCStream's EVM
+// transaction event subscription (started by initSubscriptions) reports them
+// included in a block. The pool has no size limit: a transaction that leaves
+// the mempool without being included stays in it.
+type pendingPool struct {
+	mu            sync.Mutex
+	bySenderNonce map[senderNonceKey]*rpctypes.RPCTransaction
+	byHash        map[common.Hash]senderNonceKey
+}
+
+func newPendingPool() *pendingPool {
+	return &pendingPool{
+		bySenderNonce: make(map[senderNonceKey]*rpctypes.RPCTransaction),
+		byHash:        make(map[common.Hash]senderNonceKey),
+	}
+}
+
+// Add records a newly observed pending transaction, replacing any existing
+// transaction from the same sender with the same nonce (e.g. a fee bump).
+func (p *pendingPool) Add(tx *rpctypes.RPCTransaction) {
+	if tx == nil || tx.From == (common.Address{}) {
+		return
+	}
+
+	key := senderNonceKey{sender: tx.From, nonce: uint64(tx.Nonce)}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if old, ok := p.bySenderNonce[key]; ok {
+		delete(p.byHash, old.Hash)
+	}
+	p.bySenderNonce[key] = tx
+	p.byHash[tx.Hash] = key
+}
+
+// Remove evicts a transaction once it has been included in a block.
+func (p *pendingPool) Remove(hash common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key, ok := p.byHash[hash]
+	if !ok {
+		return
+	}
+	delete(p.byHash, hash)
+	delete(p.bySenderNonce, key)
+}
+
+// Status returns the pending/queued counts used by txpool_status. Nonce-gap
+// detection (which would move an entry from pending to queued) is not
+// implemented, so every tracked transaction is currently reported pending.
+func (p *pendingPool) Status() (pending, queued uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return uint64(len(p.bySenderNonce)), 0
+}
+
+// Content returns all tracked transactions grouped by sender and nonce, as
+// consumed by txpool_content and txpool_contentFrom.
+func (p *pendingPool) Content() map[common.Address]map[uint64]*rpctypes.RPCTransaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make(map[common.Address]map[uint64]*rpctypes.RPCTransaction, len(p.bySenderNonce))
+	for key, tx := range p.bySenderNonce {
+		byNonce, ok := out[key.sender]
+		if !ok {
+			byNonce = make(map[uint64]*rpctypes.RPCTransaction)
+			out[key.sender] = byNonce
+		}
+		byNonce[key.nonce] = tx
+	}
+	return out
+}
@@ -3,6 +3,7 @@ package stream
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 
 	"cosmossdk.io/log"
@@ -44,6 +45,13 @@ var (
 type RPCHeader struct {
 	EthHeader *ethtypes.Header
 	Hash      common.Hash
+
+	// ParentHash is the CometBFT block hash (the same hash space as Hash) of
+	// the block this header extends, i.e. data.Block.Header.LastBlockID.Hash.
+	// finalityTracker keys continuity off this rather than
+	// EthHeader.ParentHash, which is produced by EthHeaderFromTendermint and
+	// isn't guaranteed to live in the same hash space as Hash.
+	ParentHash common.Hash
 }
 
 type validatorAccountFunc func(
@@ -61,8 +69,34 @@ type RPCStream struct {
 	headerStream *Stream[RPCHeader]
 	logStream    *Stream[*ethtypes.Log]
 
-	// pendingTxStream is backed by check-tx ante handler
-	pendingTxStream *Stream[common.Hash]
+	// safeHeaderStream/finalizedHeaderStream only emit a header once the
+	// configured finality policy is satisfied for it; see finalityTracker.
+	safeHeaderStream      *Stream[RPCHeader]
+	finalizedHeaderStream *Stream[RPCHeader]
+	finality              *finalityTracker
+
+	// latestMu guards latestSafe/latestFinalized, the most recent header each
+	// stream has emitted. HeaderByNumber reads these to resolve
+	// types.SafeBlockNumber/types.FinalizedBlockNumber for callers (e.g. a
+	// backend's block resolution helpers) that need a point-in-time answer
+	// rather than a subscription.
+	latestMu        sync.RWMutex
+	latestSafe      *RPCHeader
+	latestFinalized *RPCHeader
+
+	// pendingTxStream/pendingFullTxStream are backed by the check-tx ante handler.
+	// pendingTxStream only ever carries the hash (the `newPendingTransactions`
+	// subscription without `fullTransactions`); pendingFullTxStream carries the
+	// decoded transaction, so subscribers that ask for full objects don't need
+	// a follow-up eth_getTransactionByHash.
+	pendingTxStream     *Stream[common.Hash]
+	pendingFullTxStream *Stream[*types.RPCTransaction]
+
+	// pendingPool tracks currently pending transactions keyed by sender+nonce,
+	// backing the txpool namespace.
+	pendingPool *pendingPool
+
+	chainID *big.Int
 
 	wg               sync.WaitGroup
 	validatorAccount validatorAccountFunc
@@ -73,13 +107,17 @@ func NewRPCStreams(
 	logger log.Logger,
 	txDecoder sdk.TxDecoder,
 	validatorAccount validatorAccountFunc,
+	chainID *big.Int,
 ) *RPCStream {
 	return &RPCStream{
-		evtClient:        evtClient,
-		logger:           logger,
-		txDecoder:        txDecoder,
-		validatorAccount: validatorAccount,
-		pendingTxStream:  NewStream[common.Hash](txStreamSegmentSize, txStreamCapacity),
+		evtClient:           evtClient,
+		logger:              logger,
+		txDecoder:           txDecoder,
+		validatorAccount:    validatorAccount,
+		chainID:             chainID,
+		pendingTxStream:     NewStream[common.Hash](txStreamSegmentSize, txStreamCapacity),
+		pendingFullTxStream: NewStream[*types.RPCTransaction](txStreamSegmentSize, txStreamCapacity),
+		pendingPool:         newPendingPool(),
 	}
 }
 
@@ -91,6 +129,9 @@ func (s *RPCStream) initSubscriptions() {
 
 	s.headerStream = NewStream[RPCHeader](headerStreamSegmentSize, headerStreamCapacity)
 	s.logStream = NewStream[*ethtypes.Log](logStreamSegmentSize, logStreamCapacity)
+	s.safeHeaderStream = NewStream[RPCHeader](safeHeaderStreamSegmentSize, safeHeaderStreamCapacity)
+	s.finalizedHeaderStream = NewStream[RPCHeader](finalizedHeaderStreamSegmentSize, finalizedHeaderStreamCapacity)
+	s.finality = newFinalityTracker(DefaultFinalizedDepth)
 
 	ctx := context.Background()
 
@@ -128,18 +169,107 @@ func (s *RPCStream) HeaderStream() *Stream[RPCHeader] {
 	return s.headerStream
 }
 
+// SafeHeaderStream returns the stream of headers that have become "safe",
+// backing the `safe` block tag for eth_getBlockByNumber, eth_call, filter
+// ranges and eth_subscribe("newHeads").
+func (s *RPCStream) SafeHeaderStream() *Stream[RPCHeader] {
+	s.initSubscriptions()
+	return s.safeHeaderStream
+}
+
+// FinalizedHeaderStream returns the stream of headers that have become
+// "finalized", backing the `finalized` block tag.
+func (s *RPCStream) FinalizedHeaderStream() *Stream[RPCHeader] {
+	s.initSubscriptions()
+	return s.finalizedHeaderStream
+}
+
+// HeaderByNumber resolves types.SafeBlockNumber and types.FinalizedBlockNumber
+// against the most recent header each has unlocked so far, for callers (a
+// backend's block resolution helpers, the filter system's range queries)
+// that need a point-in-time answer rather than a subscription. It reports
+// ok=false for any other BlockNumber, including the aliases
+// (latest/pending/earliest) and concrete heights a backend already knows how
+// to resolve itself, and for safe/finalized before the first header has been
+// observed.
+func (s *RPCStream) HeaderByNumber(bn types.BlockNumber) (header RPCHeader, ok bool) {
+	s.latestMu.RLock()
+	defer s.latestMu.RUnlock()
+
+	switch bn {
+	case types.SafeBlockNumber:
+		if s.latestSafe == nil {
+			return RPCHeader{}, false
+		}
+		return *s.latestSafe, true
+	case types.FinalizedBlockNumber:
+		if s.latestFinalized == nil {
+			return RPCHeader{}, false
+		}
+		return *s.latestFinalized, true
+	default:
+		return RPCHeader{}, false
+	}
+}
+
 func (s *RPCStream) PendingTxStream() *Stream[common.Hash] {
 	return s.pendingTxStream
 }
 
+// PendingFullTxStream returns the stream of decoded pending transactions,
+// consumed by the `newPendingTransactions` subscription with
+// `fullTransactions: true`.
+func (s *RPCStream) PendingFullTxStream() *Stream[*types.RPCTransaction] {
+	return s.pendingFullTxStream
+}
+
+// PendingPool returns the sender+nonce indexed view of currently pending
+// transactions, backing the txpool namespace.
+func (s *RPCStream) PendingPool() *pendingPool {
+	return s.pendingPool
+}
+
 func (s *RPCStream) LogStream() *Stream[*ethtypes.Log] {
 	s.initSubscriptions()
 	return s.logStream
 }
 
-// ListenPendingTx is a callback passed to application to listen for pending transactions in CheckTx.
-func (s *RPCStream) ListenPendingTx(hash common.Hash) {
+// ListenPendingTx is a callback passed to application to listen for pending
+// transactions in CheckTx. txBytes is the raw transaction as seen by CheckTx;
+// it's decoded here to populate the full-object pending tx stream and the
+// txpool namespace, in addition to the hash-only stream used by plain
+// `newPendingTransactions` subscribers.
+func (s *RPCStream) ListenPendingTx(txBytes []byte, hash common.Hash) {
 	s.PendingTxStream().Add(hash)
+
+	tx, err := s.txDecoder(txBytes)
+	if err != nil {
+		s.logger.Error("failed to decode pending tx", "hash", hash, "err", err)
+		return
+	}
+
+	for _, msg := range tx.GetMsgs() {
+		ethMsg, ok := msg.(*evmtypes.MsgEthereumTx)
+		if !ok {
+			continue
+		}
+
+		rpcTx, err := types.NewTransactionFromMsg(ethMsg, common.Hash{}, 0, 0, s.chainID)
+		if err != nil {
+			s.logger.Error("failed to convert pending tx", "hash", hash, "err", err)
+			continue
+		}
+		// A pending tx isn't in a block yet: force BlockHash/BlockNumber/
+		// TransactionIndex to nil regardless of whatever zero-value
+		// NewTransactionFromMsg filled in, so it serializes as
+		// `blockHash: null` like go-ethereum's pending transactions do.
+		rpcTx.BlockHash = nil
+		rpcTx.BlockNumber = nil
+		rpcTx.TransactionIndex = nil
+
+		s.pendingFullTxStream.Add(rpcTx)
+		s.pendingPool.Add(rpcTx)
+	}
 }
 
 func (s *RPCStream) start(
@@ -187,7 +317,32 @@ func (s *RPCStream) start(
 			}
 			// TODO: fetch bloom from events
 			header := types.EthHeaderFromTendermint(data.Block.Header, ethtypes.Bloom{}, baseFee, validator)
-			s.headerStream.Add(RPCHeader{EthHeader: header, Hash: common.BytesToHash(data.Block.Header.Hash())})
+			rpcHeader := RPCHeader{
+				EthHeader:  header,
+				Hash:       common.BytesToHash(data.Block.Header.Hash()),
+				ParentHash: common.BytesToHash(data.Block.Header.LastBlockID.Hash),
+			}
+			s.headerStream.Add(rpcHeader)
+
+			if safe, finalized, ok := s.finality.observe(rpcHeader, data.Block.Height); !ok {
+				s.logger.Error(
+					"detected a break in the header stream, safe/finalized tracking has been reset",
+					"height", data.Block.Height,
+				)
+			} else {
+				if safe != nil {
+					s.safeHeaderStream.Add(*safe)
+					s.latestMu.Lock()
+					s.latestSafe = safe
+					s.latestMu.Unlock()
+				}
+				if finalized != nil {
+					s.finalizedHeaderStream.Add(*finalized)
+					s.latestMu.Lock()
+					s.latestFinalized = finalized
+					s.latestMu.Unlock()
+				}
+			}
 
 		case ev, ok := <-chLogs:
 			if !ok {
@@ -217,6 +372,11 @@ func (s *RPCStream) start(
 			}
 
 			s.logStream.Add(txLogs...)
+
+			// the transaction has now landed in a block, so it's no longer pending.
+			if hashAttr, ok := ev.Events[evmTxHashKey]; ok && len(hashAttr) > 0 {
+				s.pendingPool.Remove(common.HexToHash(hashAttr[0]))
+			}
 		}
 
 		if chBlocks == nil && chLogs == nil {
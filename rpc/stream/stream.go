@@ -0,0 +1,322 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package stream
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Policy controls what a Stream does with items for a subscriber whose
+// queue is full, i.e. a subscriber that isn't draining its channel as fast
+// as the producer is publishing.
+type Policy int
+
+const (
+	// PolicyBlock makes the producer wait until the subscriber has room,
+	// matching the stream's pre-per-subscriber-queue behaviour. Unlike the
+	// other policies, this does apply backpressure all the way back to
+	// Stream.Add: a Block subscriber is an explicit opt-in to "this
+	// subscription must not miss anything, even if that means stalling
+	// publication to it (and, since Add delivers to subscribers in a single
+	// pass, to whichever other subscribers haven't been reached yet in that
+	// same Add call)". Subscribers that must never do that should use one of
+	// the other policies instead.
+	PolicyBlock Policy = iota
+	// PolicyDropOldest evicts the oldest undelivered item to make room for
+	// the new one, e.g. for `newHeads` where only the latest header matters.
+	PolicyDropOldest
+	// PolicyDropNewest discards the incoming item, keeping whatever is
+	// already queued.
+	PolicyDropNewest
+	// PolicyError closes the subscription with ErrSubscriptionQueueOverflow,
+	// the same behaviour geth uses for subscriptions that can't keep up.
+	PolicyError
+)
+
+// ErrSubscriptionQueueOverflow is delivered to a PolicyError subscription's
+// Err channel when its queue overflows.
+var ErrSubscriptionQueueOverflow = errors.New("subscription queue overflow")
+
+// DefaultCapacity is the per-subscriber queue size used when neither
+// NewStream nor Subscribe is given a positive capacity.
+const DefaultCapacity = 256
+
+var (
+	queueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "ethermint",
+		Subsystem: "rpc_stream",
+		Name:      "subscription_queue_depth",
+		Help:      "Number of buffered-but-undelivered items for an RPC stream subscription.",
+	}, []string{"namespace"})
+
+	droppedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ethermint",
+		Subsystem: "rpc_stream",
+		Name:      "subscription_dropped_total",
+		Help:      "Number of items dropped from an RPC stream subscription queue, by policy.",
+	}, []string{"namespace", "policy"})
+)
+
+func init() {
+	prometheus.MustRegister(queueDepth, droppedTotal)
+}
+
+func (p Policy) String() string {
+	switch p {
+	case PolicyBlock:
+		return "block"
+	case PolicyDropOldest:
+		return "drop_oldest"
+	case PolicyDropNewest:
+		return "drop_newest"
+	case PolicyError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Subscription is a single subscriber's view of a Stream: a channel of
+// delivered items plus an error channel that fires if the subscription is
+// closed due to an overflow (PolicyError) or an explicit Unsubscribe.
+type Subscription[T any] struct {
+	out  chan T
+	errc chan error
+
+	unsubscribeOnce sync.Once
+	unsubscribe     func()
+}
+
+// Channel returns the channel items are delivered on. It is closed when the
+// subscription ends, whether via Unsubscribe or a PolicyError overflow.
+func (sub *Subscription[T]) Channel() <-chan T { return sub.out }
+
+// Err returns a channel that receives a non-nil error if the subscription
+// was closed abnormally (currently only ErrSubscriptionQueueOverflow).
+func (sub *Subscription[T]) Err() <-chan error { return sub.errc }
+
+// Unsubscribe stops delivery and closes Channel(). Safe to call more than
+// once and safe to call after the subscription already ended on its own.
+func (sub *Subscription[T]) Unsubscribe() {
+	sub.unsubscribeOnce.Do(sub.unsubscribe)
+}
+
+// subscriber is the producer-side half of a Subscription: a hand-off queue,
+// bounded at capacity for every policy (not just the capacity of the
+// delivery channel Subscription.out), fed by Stream.Add and drained by a
+// dispatch goroutine (run) into Subscription.out.
+type subscriber[T any] struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []T
+	closed bool
+
+	policy    Policy
+	capacity  int
+	namespace string
+
+	sub *Subscription[T]
+}
+
+func newSubscriber[T any](policy Policy, capacity int, namespace string) *subscriber[T] {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+
+	s := &subscriber[T]{
+		policy:    policy,
+		capacity:  capacity,
+		namespace: namespace,
+		sub: &Subscription[T]{
+			out:  make(chan T, capacity),
+			errc: make(chan error, 1),
+		},
+	}
+	s.cond = sync.NewCond(&s.mu)
+	s.sub.unsubscribe = s.close
+	go s.run()
+	return s
+}
+
+// push hands an item to the subscriber, enforcing capacity and Policy on the
+// hand-off queue itself:
+//
+//   - PolicyBlock waits (releasing the lock via cond.Wait) until the queue
+//     has room, which is how backpressure actually reaches the caller -
+//     Stream.Add, i.e. the producer.
+//   - PolicyDropOldest/PolicyDropNewest/PolicyError never block: they evict,
+//     discard, or close the subscription on the spot, so push always returns
+//     immediately for those policies.
+func (s *subscriber[T]) push(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.queue) >= s.capacity {
+		if s.closed {
+			return
+		}
+
+		switch s.policy {
+		case PolicyBlock:
+			s.cond.Wait()
+			continue
+
+		case PolicyDropOldest:
+			s.queue = s.queue[1:]
+			droppedTotal.WithLabelValues(s.namespace, s.policy.String()).Inc()
+
+		case PolicyDropNewest:
+			droppedTotal.WithLabelValues(s.namespace, s.policy.String()).Inc()
+			return
+
+		case PolicyError:
+			droppedTotal.WithLabelValues(s.namespace, s.policy.String()).Inc()
+			s.closeLocked()
+			select {
+			case s.sub.errc <- ErrSubscriptionQueueOverflow:
+			default:
+			}
+			return
+		}
+	}
+
+	if s.closed {
+		return
+	}
+
+	s.queue = append(s.queue, item)
+	queueDepth.WithLabelValues(s.namespace).Set(float64(len(s.queue)))
+	s.cond.Signal()
+}
+
+func (s *subscriber[T]) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+}
+
+// closeLocked is close with s.mu already held.
+func (s *subscriber[T]) closeLocked() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.cond.Broadcast()
+}
+
+// run drains the hand-off queue into sub.out. It is the only goroutine that
+// ever sends on sub.out or closes it, so it owns that channel's lifecycle.
+// By the time an item reaches run, push has already enforced Policy against
+// the queue, so run only ever needs to deliver it.
+func (s *subscriber[T]) run() {
+	defer close(s.sub.out)
+
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		item := s.queue[0]
+		s.queue = s.queue[1:]
+		queueDepth.WithLabelValues(s.namespace).Set(float64(len(s.queue)))
+		s.cond.Signal() // wake a push blocked in PolicyBlock waiting for room
+		s.mu.Unlock()
+
+		s.sub.out <- item
+	}
+}
+
+// Stream fans a sequence of published items out to independent subscribers.
+// Each subscriber gets its own bounded queue and backpressure Policy, so a
+// slow or stalled subscriber with a drop or error policy never blocks the
+// producer or other subscribers. A PolicyBlock subscriber whose queue is full
+// makes Add wait: see PolicyBlock.
+type Stream[T any] struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber[T]]struct{}
+
+	// defaultCapacity backs Subscribe calls that pass capacity <= 0.
+	defaultCapacity int
+}
+
+// NewStream creates a Stream. segmentSize is accepted for backwards
+// compatibility with call sites predating the per-subscriber redesign and is
+// no longer used; capacity becomes the default per-subscriber queue size for
+// Subscribe calls that don't override it.
+func NewStream[T any](segmentSize, capacity int) *Stream[T] {
+	_ = segmentSize
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Stream[T]{
+		subscribers:     make(map[*subscriber[T]]struct{}),
+		defaultCapacity: capacity,
+	}
+}
+
+// Subscribe registers a new subscriber with the given policy and capacity
+// (0 uses the stream's default capacity), under namespace for metrics
+// labelling (e.g. "eth_logs", "eth_newHeads").
+func (s *Stream[T]) Subscribe(policy Policy, capacity int, namespace string) *Subscription[T] {
+	if capacity <= 0 {
+		capacity = s.defaultCapacity
+	}
+
+	sub := newSubscriber[T](policy, capacity, namespace)
+
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+
+	orig := sub.sub.unsubscribe
+	sub.sub.unsubscribe = func() {
+		orig()
+		s.mu.Lock()
+		delete(s.subscribers, sub)
+		s.mu.Unlock()
+	}
+
+	return sub.sub
+}
+
+// Add publishes items to every current subscriber, one subscriber at a time.
+// Subscribers with a drop or error policy never make it wait: see
+// subscriber.push. A PolicyBlock subscriber whose queue is full makes Add
+// wait until it has room, which also holds up delivery to the subscribers not
+// yet reached in this Add call. The subscriber set is snapshotted under s.mu
+// and push is called outside it, so a waiting push doesn't block a concurrent
+// Subscribe/Unsubscribe.
+func (s *Stream[T]) Add(items ...T) {
+	s.mu.Lock()
+	subs := make([]*subscriber[T], 0, len(s.subscribers))
+	for sub := range s.subscribers {
+		subs = append(subs, sub)
+	}
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		for _, item := range items {
+			sub.push(item)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package stream
+
+import (
+	"testing"
+	"time"
+)
+
+// TestStreamSlowSubscriberDoesNotStallOthers ensures a subscriber that never
+// drains its channel (simulating a stalled WebSocket client) doesn't prevent
+// other subscribers on the same Stream from receiving new items.
+func TestStreamSlowSubscriberDoesNotStallOthers(t *testing.T) {
+	s := NewStream[int](0, 0)
+
+	slow := s.Subscribe(PolicyDropOldest, 1, "logs")
+	// fast has room for every item added below: PolicyBlock makes Add wait
+	// once a subscriber's queue is full, and nothing reads fast until the
+	// loop is done.
+	fast := s.Subscribe(PolicyBlock, 10, "newHeads")
+
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+
+	select {
+	case v := <-fast.Channel():
+		if v != 0 {
+			t.Fatalf("expected first item 0, got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for fast subscriber, a slow subscriber stalled the producer")
+	}
+
+	// drain the rest so the producer side goroutine can exit cleanly.
+	for i := 1; i < 10; i++ {
+		select {
+		case <-fast.Channel():
+		case <-time.After(time.Second):
+			t.Fatal("timed out draining fast subscriber")
+		}
+	}
+
+	// the slow subscriber, with capacity 1 and DropOldest, should have kept
+	// only its most recently delivered item rather than blocking delivery.
+	select {
+	case v, ok := <-slow.Channel():
+		if !ok {
+			t.Fatal("slow subscriber channel closed unexpectedly")
+		}
+		if v != 9 {
+			t.Fatalf("expected DropOldest to retain the latest item (9), got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out reading from slow subscriber")
+	}
+
+	fast.Unsubscribe()
+	slow.Unsubscribe()
+}
+
+// TestStreamPolicyError ensures a PolicyError subscription is closed with
+// ErrSubscriptionQueueOverflow once its queue overflows.
+func TestStreamPolicyError(t *testing.T) {
+	s := NewStream[int](0, 0)
+	sub := s.Subscribe(PolicyError, 1, "logs")
+
+	s.Add(1, 2, 3)
+
+	select {
+	case err := <-sub.Err():
+		if err != ErrSubscriptionQueueOverflow {
+			t.Fatalf("expected ErrSubscriptionQueueOverflow, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for overflow error")
+	}
+
+	if _, ok := <-sub.Channel(); ok {
+		t.Fatal("expected channel to be closed after overflow")
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// BlockNumber is a JSON-RPC block number parameter that, in addition to an
+// actual height, may name one of the well-known aliases below, mirroring
+// go-ethereum's rpc.BlockNumber (including its sentinel values, so a height
+// compared against these constants behaves the same as it does against
+// go-ethereum's). SafeBlockNumber/FinalizedBlockNumber back the streams
+// produced by rpc/stream.RPCStream.SafeHeaderStream/FinalizedHeaderStream.
+type BlockNumber int64
+
+const (
+	SafeBlockNumber      = BlockNumber(-4)
+	FinalizedBlockNumber = BlockNumber(-3)
+	LatestBlockNumber    = BlockNumber(-2)
+	PendingBlockNumber   = BlockNumber(-1)
+	EarliestBlockNumber  = BlockNumber(0)
+)
+
+// UnmarshalJSON parses the given JSON fragment into a BlockNumber. It
+// accepts the string aliases "earliest", "latest", "pending", "safe" and
+// "finalized", or a hex-encoded integer, matching go-ethereum's
+// rpc.BlockNumber so existing client tooling that already sends "safe"/
+// "finalized" against a geth-compatible node needs no changes here.
+func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
+	input := strings.TrimSpace(string(data))
+	if len(input) >= 2 && input[0] == '"' && input[len(input)-1] == '"' {
+		input = input[1 : len(input)-1]
+	}
+
+	switch input {
+	case "earliest":
+		*bn = EarliestBlockNumber
+		return nil
+	case "latest":
+		*bn = LatestBlockNumber
+		return nil
+	case "pending":
+		*bn = PendingBlockNumber
+		return nil
+	case "safe":
+		*bn = SafeBlockNumber
+		return nil
+	case "finalized":
+		*bn = FinalizedBlockNumber
+		return nil
+	}
+
+	blockNumber, err := hexOrDecimalToBigInt(input)
+	if err != nil {
+		return err
+	}
+	if !blockNumber.IsInt64() {
+		return fmt.Errorf("block number larger than int64: %s", input)
+	}
+	*bn = BlockNumber(blockNumber.Int64())
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (bn BlockNumber) MarshalText() ([]byte, error) {
+	switch bn {
+	case EarliestBlockNumber:
+		return []byte("earliest"), nil
+	case LatestBlockNumber:
+		return []byte("latest"), nil
+	case PendingBlockNumber:
+		return []byte("pending"), nil
+	case SafeBlockNumber:
+		return []byte("safe"), nil
+	case FinalizedBlockNumber:
+		return []byte("finalized"), nil
+	default:
+		return []byte(fmt.Sprintf("0x%x", int64(bn))), nil
+	}
+}
+
+// Int64 returns the BlockNumber as an int64.
+func (bn BlockNumber) Int64() int64 { return int64(bn) }
+
+// IsAlias reports whether bn names a well-known tag rather than a concrete
+// height.
+func (bn BlockNumber) IsAlias() bool {
+	switch bn {
+	case EarliestBlockNumber, LatestBlockNumber, PendingBlockNumber, SafeBlockNumber, FinalizedBlockNumber:
+		return true
+	default:
+		return false
+	}
+}
+
+func hexOrDecimalToBigInt(input string) (*big.Int, error) {
+	raw := input
+	if strings.HasPrefix(raw, "0x") || strings.HasPrefix(raw, "0X") {
+		n, ok := new(big.Int).SetString(raw[2:], 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid hex block number: %s", input)
+		}
+		return n, nil
+	}
+
+	var n json.Number = json.Number(raw)
+	i, err := n.Int64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number: %s", input)
+	}
+	return big.NewInt(i), nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+// Package config holds the node-operator-facing configuration for
+// Ethermint's JSON-RPC/WS/IPC/GraphQL/admin servers, loaded from the
+// app.toml `[json-rpc]` and `[api]` sections.
+package config
+
+import "time"
+
+const (
+	// DefaultJSONRPCAddress is the default address the public JSON-RPC HTTP
+	// server listens on.
+	DefaultJSONRPCAddress = "0.0.0.0:8545"
+
+	// DefaultJSONRPCWsAddress is the default address the public JSON-RPC
+	// WebSocket server listens on.
+	DefaultJSONRPCWsAddress = "0.0.0.0:8546"
+
+	// DefaultJSONRPCMetricsAddress is kept here only for callers that still
+	// reference it; metrics have their own server elsewhere.
+	DefaultHTTPTimeout     = 30 * time.Second
+	DefaultHTTPIdleTimeout = 120 * time.Second
+)
+
+// Config defines the server's top-level configuration, mirroring the
+// app.toml sections it's parsed from.
+type Config struct {
+	JSONRPC JSONRPCConfig `mapstructure:"json-rpc"`
+	API     APIConfig     `mapstructure:"api"`
+}
+
+// APIConfig defines the Cosmos SDK gRPC/REST `[api]` settings this server
+// package reads.
+type APIConfig struct {
+	// EnableUnsafeCORS, when true, serves the public JSON-RPC endpoint with
+	// a permissive CORS policy instead of the conservative default.
+	EnableUnsafeCORS bool `mapstructure:"enabled-unsafe-cors"`
+}
+
+// JSONRPCConfig defines the `[json-rpc]` section: the public HTTP/WS
+// endpoint, the optional local-only IPC and GraphQL endpoints, and the
+// optional JWT-authenticated privileged endpoint.
+type JSONRPCConfig struct {
+	// Enable defines whether the JSON-RPC server should be enabled.
+	Enable bool `mapstructure:"enable"`
+
+	// API defines a list of JSON-RPC namespaces to register on the public
+	// HTTP/WS endpoint, e.g. ["eth", "net", "web3"]. Privileged namespaces
+	// such as "personal", "debug", "miner" and "admin" should not be listed
+	// here; they are only ever reachable via IPC or the authenticated
+	// endpoint.
+	API []string `mapstructure:"api"`
+
+	// Address is the public JSON-RPC HTTP server's listen address.
+	Address string `mapstructure:"address"`
+	// WsAddress is the public JSON-RPC WebSocket server's listen address.
+	WsAddress string `mapstructure:"ws-address"`
+
+	// IPCPath is the filesystem path (Unix) or named pipe path (Windows) for
+	// the IPC endpoint. Empty disables it. Unlike the public HTTP endpoint,
+	// IPC is not filtered by API/AllowMethods/DenyMethods: it serves the
+	// namespaces listed in API plus txpool, personal, debug and miner (admin
+	// is only served on the authenticated endpoint), on the assumption that
+	// only local, trusted processes can reach a Unix socket or named pipe.
+	IPCPath string `mapstructure:"ipc-path"`
+
+	// EnableGraphQL enables the vendored GraphQL endpoint alongside the
+	// JSON-RPC HTTP server.
+	EnableGraphQL bool `mapstructure:"enable-graphql"`
+
+	// AllowMethods, if non-empty, restricts the public HTTP endpoint to
+	// exactly these method names; DenyMethods, evaluated after AllowMethods,
+	// removes specific methods from whatever AllowMethods would otherwise
+	// permit. A request for a filtered-out method gets a -32601 (method not
+	// found) error, the code a genuinely unregistered method gets. WebSocket
+	// subscriptions are not filtered.
+	AllowMethods []string `mapstructure:"allow-methods"`
+	DenyMethods  []string `mapstructure:"deny-methods"`
+
+	// AuthAddress is the listen address for the JWT-authenticated endpoint
+	// that serves the privileged namespaces (debug, personal, miner, admin).
+	// Empty disables it.
+	AuthAddress string `mapstructure:"auth-address"`
+	// AuthAPI lists the namespaces registered on the authenticated endpoint.
+	// Empty registers the namespaces listed in API plus txpool, personal,
+	// debug and miner. The admin namespace is always registered there.
+	AuthAPI []string `mapstructure:"auth-api"`
+	// JWTSecret is a path to a hex-encoded 32-byte secret used to verify the
+	// authenticated endpoint's bearer tokens, matching go-ethereum's Engine
+	// API auth scheme. Empty auto-generates and persists one on first start.
+	JWTSecret string `mapstructure:"jwt-secret"`
+
+	// AllowUnprotectedTxs allows send and sign methods for JSON-RPC
+	// transactions without replay protection (EIP155).
+	AllowUnprotectedTxs bool `mapstructure:"allow-unprotected-txs"`
+
+	// HTTPTimeout is the read/write timeout applied to the public and
+	// authenticated HTTP servers.
+	HTTPTimeout time.Duration `mapstructure:"http-timeout"`
+	// HTTPIdleTimeout is the idle-connection timeout applied to the public
+	// and authenticated HTTP servers.
+	HTTPIdleTimeout time.Duration `mapstructure:"http-idle-timeout"`
+}
+
+// DefaultConfig returns the default server configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		JSONRPC: JSONRPCConfig{
+			Enable:              true,
+			API:                 []string{"eth", "net", "web3"},
+			Address:             DefaultJSONRPCAddress,
+			WsAddress:           DefaultJSONRPCWsAddress,
+			IPCPath:             "",
+			EnableGraphQL:       false,
+			AllowMethods:        []string{},
+			DenyMethods:         []string{},
+			AuthAddress:         "",
+			AuthAPI:             []string{},
+			JWTSecret:           "",
+			AllowUnprotectedTxs: false,
+			HTTPTimeout:         DefaultHTTPTimeout,
+			HTTPIdleTimeout:     DefaultHTTPIdleTimeout,
+		},
+		API: APIConfig{
+			EnableUnsafeCORS: false,
+		},
+	}
+}
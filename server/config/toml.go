@@ -0,0 +1,56 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package config
+
+// DefaultConfigTemplate is appended to app.toml under the `[json-rpc]`
+// section for the fields this package adds on top of the upstream template.
+const DefaultConfigTemplate = `
+###############################################################################
+###                             JSON RPC (cont.)                          ###
+###############################################################################
+
+# IPCPath is the filesystem path (Unix) or named pipe path (Windows) the IPC
+# endpoint listens on. Leave empty to disable IPC. IPC is not subject to
+# AllowMethods/DenyMethods: it serves the public namespaces plus txpool,
+# personal, debug and miner, so only enable it where the socket/pipe itself
+# is access-controlled.
+ipc-path = "{{ .JSONRPC.IPCPath }}"
+
+# EnableGraphQL enables the GraphQL endpoint alongside JSON-RPC.
+enable-graphql = {{ .JSONRPC.EnableGraphQL }}
+
+# AllowMethods, if non-empty, restricts the public endpoint to exactly these
+# JSON-RPC methods. DenyMethods removes methods from whatever AllowMethods
+# would otherwise permit (or from the full method set, if AllowMethods is
+# empty).
+allow-methods = [{{ range .JSONRPC.AllowMethods }}{{ printf "%q, " . }}{{ end }}]
+deny-methods = [{{ range .JSONRPC.DenyMethods }}{{ printf "%q, " . }}{{ end }}]
+
+# AuthAddress is the listen address for the JWT-authenticated endpoint that
+# serves privileged namespaces (debug, personal, miner, admin). Leave empty
+# to disable it.
+auth-address = "{{ .JSONRPC.AuthAddress }}"
+
+# AuthAPI lists the namespaces registered on the authenticated endpoint.
+# Leave empty to register the public endpoint's namespaces plus txpool,
+# personal, debug and miner.
+auth-api = [{{ range .JSONRPC.AuthAPI }}{{ printf "%q, " . }}{{ end }}]
+
+# JWTSecret is a path to a hex-encoded 32-byte secret securing the
+# authenticated endpoint. Leave empty to auto-generate and persist one
+# (jwt.hex, under the node's home directory) on first start.
+jwt-secret = "{{ .JSONRPC.JWTSecret }}"
+`
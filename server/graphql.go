@@ -0,0 +1,599 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"cosmossdk.io/log"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/gorilla/mux"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/evmos/ethermint/rpc/backend"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+)
+
+// go-ethereum's own `graphql` package is built on `internal/ethapi.Backend`,
+// which - being under `internal/` - cannot be implemented or imported from
+// outside the go-ethereum module. rpc/backend.Backend therefore can't satisfy
+// it, and `graphql.New` (which in go-ethereum registers a handler on a
+// `*node.Node`, not something we have here) isn't usable as-is. Instead this
+// vendors the standard Ethereum GraphQL schema - blocks, transactions, logs,
+// accounts, eth_call, and the pending block - directly against evmBackend,
+// the same rpc/backend instance the eth namespace APIs are built from.
+const graphQLSchema = `
+	schema {
+		query: Query
+	}
+
+	# Long is a 64-bit unsigned integer, represented as its decimal string
+	# form: the uint64 fields it backs (block numbers, gas, nonces,
+	# timestamps) all overflow graphql-go's native Int (int32) well within a
+	# live chain's lifetime.
+	scalar Long
+
+	type Query {
+		block(number: Long, hash: String): Block
+		transaction(hash: String!): Transaction
+		account(address: String!, blockNumber: Long): Account!
+		call(data: CallData!, blockNumber: Long): CallResult!
+		pending: Pending!
+	}
+
+	type Block {
+		number: Long!
+		hash: String!
+		parentHash: String!
+		timestamp: Long!
+		gasLimit: Long!
+		gasUsed: Long!
+		transactions: [Transaction!]!
+		logs(filter: BlockLogsFilter): [Log!]!
+	}
+
+	input BlockLogsFilter {
+		addresses: [String!]
+		topics: [String!]
+	}
+
+	type Transaction {
+		hash: String!
+		nonce: Long!
+		from: String!
+		to: String
+		value: String!
+		gas: Long!
+		gasPrice: String!
+		input: String!
+		blockHash: String
+		blockNumber: Long
+		logs: [Log!]!
+	}
+
+	type Log {
+		index: Long!
+		account: Account!
+		topics: [String!]!
+		data: String!
+		transaction: Transaction!
+	}
+
+	type Account {
+		address: String!
+		balance: String!
+		transactionCount: Long!
+		code: String!
+	}
+
+	input CallData {
+		from: String
+		to: String
+		gas: Long
+		gasPrice: String
+		value: String
+		data: String
+	}
+
+	type CallResult {
+		data: String!
+		gasUsed: Long!
+		status: Long!
+	}
+
+	type Pending {
+		transactionCount: Int!
+		transactions: [Transaction!]!
+	}
+`
+
+// gqlLong implements graphql-go's custom-scalar interfaces for a uint64,
+// avoiding the int32 truncation/overflow the Int built-in scalar would
+// otherwise impose on block numbers, gas and timestamps.
+type gqlLong uint64
+
+func (l gqlLong) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+func (l *gqlLong) UnmarshalGraphQL(input interface{}) error {
+	switch v := input.(type) {
+	case int32:
+		*l = gqlLong(v)
+	case int64:
+		*l = gqlLong(v)
+	case float64:
+		*l = gqlLong(v)
+	case string:
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		*l = gqlLong(n)
+	default:
+		return fmt.Errorf("unexpected type for Long: %T", input)
+	}
+	return nil
+}
+
+func (l gqlLong) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatUint(uint64(l), 10))
+}
+
+// gqlTransaction is the GraphQL-facing shape of an eth transaction, built
+// from rpctypes.RPCTransaction so both the `transaction` query and a block's
+// nested `transactions` use the same conversion.
+type gqlTransaction struct {
+	backend *backend.Backend
+	tx      *rpctypes.RPCTransaction
+}
+
+func newGQLTransaction(backend *backend.Backend, tx *rpctypes.RPCTransaction) *gqlTransaction {
+	if tx == nil {
+		return nil
+	}
+	return &gqlTransaction{backend: backend, tx: tx}
+}
+
+func (t *gqlTransaction) Hash() string   { return t.tx.Hash.Hex() }
+func (t *gqlTransaction) Nonce() gqlLong { return gqlLong(t.tx.Nonce) }
+func (t *gqlTransaction) From() string   { return t.tx.From.Hex() }
+func (t *gqlTransaction) Gas() gqlLong   { return gqlLong(t.tx.Gas) }
+func (t *gqlTransaction) Input() string  { return t.tx.Input.String() }
+
+func (t *gqlTransaction) Value() string {
+	if t.tx.Value == nil {
+		return "0x0"
+	}
+	return t.tx.Value.String()
+}
+
+func (t *gqlTransaction) GasPrice() string {
+	if t.tx.GasPrice == nil {
+		return "0x0"
+	}
+	return t.tx.GasPrice.String()
+}
+
+func (t *gqlTransaction) To() *string {
+	if t.tx.To == nil {
+		return nil
+	}
+	hex := t.tx.To.Hex()
+	return &hex
+}
+
+func (t *gqlTransaction) BlockHash() *string {
+	if t.tx.BlockHash == nil {
+		return nil
+	}
+	hex := t.tx.BlockHash.Hex()
+	return &hex
+}
+
+func (t *gqlTransaction) BlockNumber() *gqlLong {
+	if t.tx.BlockNumber == nil {
+		return nil
+	}
+	n := gqlLong(t.tx.BlockNumber.ToInt().Uint64())
+	return &n
+}
+
+func (t *gqlTransaction) Logs() ([]*gqlLog, error) {
+	if t.tx.BlockHash == nil {
+		// a pending transaction has no receipt yet.
+		return []*gqlLog{}, nil
+	}
+
+	logs, err := t.backend.GetLogs(*t.tx.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*gqlLog, 0, len(logs))
+	for _, l := range logs {
+		if l.TxHash == t.tx.Hash {
+			out = append(out, newGQLLog(t.backend, t, l))
+		}
+	}
+	return out, nil
+}
+
+// gqlLog is the GraphQL-facing shape of an eth log.
+type gqlLog struct {
+	backend *backend.Backend
+	tx      *gqlTransaction
+	log     *ethtypes.Log
+}
+
+func newGQLLog(b *backend.Backend, tx *gqlTransaction, log *ethtypes.Log) *gqlLog {
+	return &gqlLog{backend: b, tx: tx, log: log}
+}
+
+func (l *gqlLog) Index() gqlLong { return gqlLong(l.log.Index) }
+func (l *gqlLog) Data() string   { return hexutil.Encode(l.log.Data) }
+
+func (l *gqlLog) Topics() []string {
+	topics := make([]string, len(l.log.Topics))
+	for i, t := range l.log.Topics {
+		topics[i] = t.Hex()
+	}
+	return topics
+}
+
+func (l *gqlLog) Account() *gqlAccount {
+	return &gqlAccount{backend: l.backend, address: l.log.Address, blockNr: rpctypes.LatestBlockNumber}
+}
+
+func (l *gqlLog) Transaction() *gqlTransaction { return l.tx }
+
+// gqlAccount is the GraphQL-facing shape of an account's state at a given
+// block, backing both the `account` query and a log's `account` field.
+type gqlAccount struct {
+	backend *backend.Backend
+	address common.Address
+	blockNr rpctypes.BlockNumber
+}
+
+func (a *gqlAccount) Address() string { return a.address.Hex() }
+
+func (a *gqlAccount) Balance() (string, error) {
+	balance, err := a.backend.GetBalance(a.address, a.blockNr)
+	if err != nil {
+		return "", err
+	}
+	return balance.String(), nil
+}
+
+func (a *gqlAccount) TransactionCount() (gqlLong, error) {
+	nonce, err := a.backend.GetTransactionCount(a.address, a.blockNr)
+	if err != nil {
+		return 0, err
+	}
+	return gqlLong(*nonce), nil
+}
+
+func (a *gqlAccount) Code() (string, error) {
+	code, err := a.backend.GetCode(a.address, a.blockNr)
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(code), nil
+}
+
+// gqlCallResult is the GraphQL-facing shape of an eth_call result.
+type gqlCallResult struct {
+	data    []byte
+	gasUsed uint64
+	status  uint64
+}
+
+func (r *gqlCallResult) Data() string     { return hexutil.Encode(r.data) }
+func (r *gqlCallResult) GasUsed() gqlLong { return gqlLong(r.gasUsed) }
+func (r *gqlCallResult) Status() gqlLong  { return gqlLong(r.status) }
+
+// gqlPending is the GraphQL-facing shape of the pending block: the
+// transactions the txpool namespace would report under "pending".
+type gqlPending struct {
+	backend *backend.Backend
+}
+
+func (p *gqlPending) TransactionCount() int32 {
+	pending, _ := p.backend.PendingPool().Status()
+	return int32(pending)
+}
+
+func (p *gqlPending) Transactions() []*gqlTransaction {
+	byAddr := p.backend.PendingPool().Content()
+
+	txs := make([]*gqlTransaction, 0, len(byAddr))
+	for _, byNonce := range byAddr {
+		for _, tx := range byNonce {
+			txs = append(txs, newGQLTransaction(p.backend, tx))
+		}
+	}
+	return txs
+}
+
+// gqlBlock is the GraphQL-facing shape of an eth block header.
+type gqlBlock struct {
+	backend *backend.Backend
+	header  *ethHeader
+}
+
+// ethHeader is the subset of *ethtypes.Header fields this schema exposes.
+type ethHeader struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+	Time       uint64
+	GasLimit   uint64
+	GasUsed    uint64
+}
+
+func (b *gqlBlock) Number() gqlLong    { return gqlLong(b.header.Number) }
+func (b *gqlBlock) Hash() string       { return b.header.Hash.Hex() }
+func (b *gqlBlock) ParentHash() string { return b.header.ParentHash.Hex() }
+func (b *gqlBlock) Timestamp() gqlLong { return gqlLong(b.header.Time) }
+func (b *gqlBlock) GasLimit() gqlLong  { return gqlLong(b.header.GasLimit) }
+func (b *gqlBlock) GasUsed() gqlLong   { return gqlLong(b.header.GasUsed) }
+
+func (b *gqlBlock) Transactions() ([]*gqlTransaction, error) {
+	block, err := b.backend.BlockByNumber(rpctypes.BlockNumber(b.header.Number))
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*gqlTransaction, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		rpcTx, err := b.backend.GetTransactionByHash(tx.Hash())
+		if err != nil {
+			continue
+		}
+		txs = append(txs, newGQLTransaction(b.backend, rpcTx))
+	}
+	return txs, nil
+}
+
+// blockLogsFilterArgs mirrors the BlockLogsFilter input type.
+type blockLogsFilterArgs struct {
+	Addresses *[]string
+	Topics    *[]string
+}
+
+// Logs returns every log emitted in this block, optionally narrowed by
+// filter.addresses/filter.topics (an OR match against either field, like
+// go-ethereum's graphql Block.logs).
+func (b *gqlBlock) Logs(args struct{ Filter *blockLogsFilterArgs }) ([]*gqlLog, error) {
+	logs, err := b.backend.GetLogs(b.header.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var wantAddr map[common.Address]bool
+	var wantTopic map[string]bool
+	if args.Filter != nil {
+		if args.Filter.Addresses != nil {
+			wantAddr = make(map[common.Address]bool, len(*args.Filter.Addresses))
+			for _, a := range *args.Filter.Addresses {
+				wantAddr[common.HexToAddress(a)] = true
+			}
+		}
+		if args.Filter.Topics != nil {
+			wantTopic = make(map[string]bool, len(*args.Filter.Topics))
+			for _, t := range *args.Filter.Topics {
+				wantTopic[t] = true
+			}
+		}
+	}
+
+	out := make([]*gqlLog, 0, len(logs))
+	for _, l := range logs {
+		if wantAddr != nil && !wantAddr[l.Address] {
+			continue
+		}
+		if wantTopic != nil {
+			matched := false
+			for _, t := range l.Topics {
+				if wantTopic[t.Hex()] {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		rpcTx, err := b.backend.GetTransactionByHash(l.TxHash)
+		if err != nil {
+			continue
+		}
+		out = append(out, newGQLLog(b.backend, newGQLTransaction(b.backend, rpcTx), l))
+	}
+	return out, nil
+}
+
+// resolver implements the root Query type against evmBackend.
+type resolver struct {
+	backend *backend.Backend
+}
+
+type blockArgs struct {
+	Number *gqlLong
+	Hash   *string
+}
+
+func (r *resolver) Block(ctx context.Context, args blockArgs) (*gqlBlock, error) {
+	blockNr := rpctypes.LatestBlockNumber
+	if args.Number != nil {
+		blockNr = rpctypes.BlockNumber(*args.Number)
+	}
+
+	var header *ethHeader
+	if args.Hash != nil {
+		h, err := r.backend.HeaderByHash(common.HexToHash(*args.Hash))
+		if err != nil {
+			return nil, err
+		}
+		header = toEthHeader(h)
+	} else {
+		h, err := r.backend.HeaderByNumber(blockNr)
+		if err != nil {
+			return nil, err
+		}
+		header = toEthHeader(h)
+	}
+
+	return &gqlBlock{backend: r.backend, header: header}, nil
+}
+
+type transactionArgs struct {
+	Hash string
+}
+
+func (r *resolver) Transaction(ctx context.Context, args transactionArgs) (*gqlTransaction, error) {
+	tx, err := r.backend.GetTransactionByHash(common.HexToHash(args.Hash))
+	if err != nil {
+		return nil, err
+	}
+	return newGQLTransaction(r.backend, tx), nil
+}
+
+type accountArgs struct {
+	Address     string
+	BlockNumber *gqlLong
+}
+
+func (r *resolver) Account(ctx context.Context, args accountArgs) (*gqlAccount, error) {
+	blockNr := rpctypes.LatestBlockNumber
+	if args.BlockNumber != nil {
+		blockNr = rpctypes.BlockNumber(*args.BlockNumber)
+	}
+	return &gqlAccount{backend: r.backend, address: common.HexToAddress(args.Address), blockNr: blockNr}, nil
+}
+
+// callData mirrors the CallData input type.
+type callData struct {
+	From     *string
+	To       *string
+	Gas      *gqlLong
+	GasPrice *string
+	Value    *string
+	Data     *string
+}
+
+type callArgs struct {
+	Data        callData
+	BlockNumber *gqlLong
+}
+
+func (r *resolver) Call(ctx context.Context, args callArgs) (*gqlCallResult, error) {
+	blockNr := rpctypes.LatestBlockNumber
+	if args.BlockNumber != nil {
+		blockNr = rpctypes.BlockNumber(*args.BlockNumber)
+	}
+
+	txArgs := rpctypes.CallArgs{}
+	if args.Data.From != nil {
+		from := common.HexToAddress(*args.Data.From)
+		txArgs.From = &from
+	}
+	if args.Data.To != nil {
+		to := common.HexToAddress(*args.Data.To)
+		txArgs.To = &to
+	}
+	if args.Data.Gas != nil {
+		gas := hexutil.Uint64(*args.Data.Gas)
+		txArgs.Gas = &gas
+	}
+	if args.Data.GasPrice != nil {
+		txArgs.GasPrice = (*hexutil.Big)(hexutil.MustDecodeBig(*args.Data.GasPrice))
+	}
+	if args.Data.Value != nil {
+		txArgs.Value = (*hexutil.Big)(hexutil.MustDecodeBig(*args.Data.Value))
+	}
+	if args.Data.Data != nil {
+		data := hexutil.MustDecode(*args.Data.Data)
+		txArgs.Data = (*hexutil.Bytes)(&data)
+	}
+
+	res, err := r.backend.DoCall(txArgs, blockNr)
+	if err != nil {
+		return nil, err
+	}
+
+	status := uint64(1)
+	if res.Failed() {
+		status = 0
+	}
+	return &gqlCallResult{data: res.Ret, gasUsed: res.GasUsed, status: status}, nil
+}
+
+func (r *resolver) Pending(ctx context.Context) (*gqlPending, error) {
+	return &gqlPending{backend: r.backend}, nil
+}
+
+// toEthHeader adapts a *ethtypes.Header (the concrete type returned by
+// evmBackend.HeaderByNumber/HeaderByHash) to the minimal shape this schema
+// needs.
+func toEthHeader(h *ethtypes.Header) *ethHeader {
+	return &ethHeader{
+		Number:     h.Number.Uint64(),
+		Hash:       h.Hash(),
+		ParentHash: h.ParentHash,
+		Time:       h.Time,
+		GasLimit:   h.GasLimit,
+		GasUsed:    h.GasUsed,
+	}
+}
+
+// registerGraphQL mounts the vendored GraphQL schema on the given router. It
+// is served from the same listener and errgroup as the JSON-RPC HTTP server,
+// so its lifecycle and shutdown semantics are identical.
+func registerGraphQL(logger log.Logger, r *mux.Router, evmBackend *backend.Backend, apiPath, uiPath string) error {
+	schema, err := graphql.ParseSchema(graphQLSchema, &resolver{backend: evmBackend})
+	if err != nil {
+		return err
+	}
+
+	r.Handle(apiPath, &relay.Handler{Schema: schema}).Methods("GET", "POST", "OPTIONS")
+
+	if uiPath != "" {
+		r.PathPrefix(uiPath).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(graphQLPlaygroundHTML(apiPath)))
+		})
+	}
+
+	logger.Info("Enabled GraphQL endpoint", "path", apiPath)
+	return nil
+}
+
+// graphQLPlaygroundHTML renders a minimal static page pointed at apiPath.
+// go-ethereum's `graphql.UIHandler` doesn't exist as an importable symbol
+// (the UI it serves is baked into its own node-bound handler), so this is a
+// small stand-in rather than a reuse of upstream code.
+func graphQLPlaygroundHTML(apiPath string) string {
+	return `<!DOCTYPE html><html><head><title>Ethermint GraphQL</title></head>` +
+		`<body><p>GraphQL endpoint: <code>` + apiPath + `</code></p></body></html>`
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package server
+
+import (
+	"context"
+
+	"cosmossdk.io/log"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/sync/errgroup"
+)
+
+// startIPCEndpoint creates an IPC listener (a Unix domain socket on Linux/macOS,
+// a named pipe on Windows) and serves the given RPC server on it. Unlike the
+// HTTP and WebSocket endpoints, the IPC endpoint is not subject to CORS or the
+// HTTP API allowlist: every registered namespace is reachable, so operators
+// should only expose it to local, trusted processes (key management tools,
+// `debug`/`personal`/`miner` consoles, etc.), mirroring go-ethereum's `geth.ipc`.
+func startIPCEndpoint(
+	ctx context.Context,
+	g *errgroup.Group,
+	logger log.Logger,
+	ipcPath string,
+	rpcServer *ethrpc.Server,
+) error {
+	listener, err := ipcListen(ipcPath)
+	if err != nil {
+		return err
+	}
+
+	g.Go(func() error {
+		logger.Info("Starting JSON-RPC IPC server", "path", ipcPath)
+		go rpcServer.ServeListener(listener)
+
+		<-ctx.Done()
+		logger.Info("stopping JSON-RPC IPC server...", "path", ipcPath)
+		return listener.Close()
+	})
+
+	return nil
+}
+
+// ipcListen is implemented per-platform: ipc_unix.go for Unix domain sockets,
+// ipc_windows.go for named pipes.
@@ -0,0 +1,46 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+//go:build !windows
+
+package server
+
+import (
+	"net"
+	"os"
+)
+
+// ipcListen creates a Unix domain socket listener at the given path, removing
+// any stale socket file left behind by a previous, uncleanly terminated run.
+func ipcListen(endpoint string) (net.Listener, error) {
+	if err := os.Remove(endpoint); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// the socket file should only be readable/writable by the user running the
+	// node, since it grants access to privileged namespaces.
+	if err := os.Chmod(endpoint, 0o600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+//go:build windows
+
+package server
+
+import (
+	"net"
+
+	"gopkg.in/natefinch/npipe.v2"
+)
+
+// ipcListen creates a named pipe listener at the given path, following the
+// same `\\.\pipe\<name>` convention go-ethereum uses for `geth.ipc` on Windows.
+func ipcListen(endpoint string) (net.Listener, error) {
+	return npipe.Listen(endpoint)
+}
@@ -28,10 +28,13 @@ import (
 	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/ethereum/go-ethereum/common"
 	ethlog "github.com/ethereum/go-ethereum/log"
 	ethrpc "github.com/ethereum/go-ethereum/rpc"
-	"github.com/evmos/ethermint/app/ante"
 	"github.com/evmos/ethermint/rpc"
+	"github.com/evmos/ethermint/rpc/backend"
+	"github.com/evmos/ethermint/rpc/namespaces/ethereum/admin"
+	"github.com/evmos/ethermint/rpc/namespaces/ethereum/txpool"
 	"github.com/evmos/ethermint/rpc/stream"
 	rpctypes "github.com/evmos/ethermint/rpc/types"
 	"github.com/evmos/ethermint/server/config"
@@ -43,8 +46,61 @@ const (
 	MaxRetry        = 6
 )
 
+// privilegedNamespaces lists the namespaces that IPC - reachable only from a
+// local, trusted process - exposes unconditionally alongside whatever the
+// public allowlist (config.JSONRPC.API) already grants. The public HTTP
+// endpoint serves them only if they are listed in that allowlist.
+var privilegedNamespaces = []string{"txpool", "personal", "debug", "miner"}
+
+// ipcNamespaceArr returns the full set of namespaces the IPC endpoint
+// registers: the public allowlist plus every privileged namespace, so IPC
+// isn't limited to config.JSONRPC.API the way the HTTP/WS endpoint is.
+func ipcNamespaceArr(publicAPIArr []string) []string {
+	seen := make(map[string]bool, len(publicAPIArr)+len(privilegedNamespaces))
+	arr := make([]string, 0, len(publicAPIArr)+len(privilegedNamespaces))
+	for _, ns := range append(append([]string{}, publicAPIArr...), privilegedNamespaces...) {
+		if seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		arr = append(arr, ns)
+	}
+	return arr
+}
+
+func hasNamespace(apiArr []string, namespace string) bool {
+	for _, ns := range apiArr {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// registerTxPool registers the txpool namespace, backed by rpcStream's
+// pending pool, on srv - if and only if namespace is present in apiArr. Like
+// the admin namespace, txpool isn't produced by rpc.GetRPCAPIs (it predates
+// this namespace), so it's registered directly here rather than folded into
+// the apis slice.
+func registerTxPool(srv *ethrpc.Server, rpcStream *stream.RPCStream, apiArr []string) error {
+	if !hasNamespace(apiArr, "txpool") {
+		return nil
+	}
+	return srv.RegisterName("txpool", txpool.NewPublicTxPoolAPI(rpcStream.PendingPool()))
+}
+
+// PendingTxListener is the callback signature the application's CheckTx ante
+// handler invokes for each pending transaction it sees: the raw tx bytes (so
+// the listener can decode exactly what was broadcast, rather than re-deriving
+// it from a partially-processed sdk.Tx) and its hash. This must match
+// ante.PendingTxListener's signature (app/ante, outside this package) -
+// RPCStream.ListenPendingTx takes (txBytes, hash) to feed both the hash-only
+// and full-object pending tx streams, so the ante handler's call site needs
+// updating to pass the raw bytes alongside the hash it already has.
+type PendingTxListener func(txBytes []byte, hash common.Hash)
+
 type AppWithPendingTxStream interface {
-	RegisterPendingTxListener(listener ante.PendingTxListener)
+	RegisterPendingTxListener(listener PendingTxListener)
 }
 
 // StartJSONRPC starts the JSON-RPC server
@@ -64,11 +120,15 @@ func StartJSONRPC(
 		return nil, fmt.Errorf("client %T does not implement EventsClient", clientCtx.Client)
 	}
 
+	chainID, err := ethermint.ParseChainID(clientCtx.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
 	var rpcStream *stream.RPCStream
-	var err error
 	queryClient := rpctypes.NewQueryClient(clientCtx)
 	for i := 0; i < MaxRetry; i++ {
-		rpcStream, err = stream.NewRPCStreams(evtClient, logger, clientCtx.TxConfig.TxDecoder(), queryClient.ValidatorAccount)
+		rpcStream, err = stream.NewRPCStreams(evtClient, logger, clientCtx.TxConfig.TxDecoder(), queryClient.ValidatorAccount, chainID)
 		if err == nil {
 			break
 		}
@@ -98,6 +158,10 @@ func StartJSONRPC(
 	allowUnprotectedTxs := config.JSONRPC.AllowUnprotectedTxs
 	rpcAPIArr := config.JSONRPC.API
 
+	// evmBackend is the single source of truth for block/tx/log lookups and
+	// fee/gas semantics: it backs the eth namespace APIs below and, when
+	// enabled, the GraphQL endpoint as well.
+	evmBackend := backend.NewBackend(srvCtx, logger, clientCtx, allowUnprotectedTxs)
 	apis := rpc.GetRPCAPIs(srvCtx, clientCtx, rpcStream, allowUnprotectedTxs, indexer, rpcAPIArr)
 
 	for _, api := range apis {
@@ -111,8 +175,19 @@ func StartJSONRPC(
 		}
 	}
 
+	if err := registerTxPool(rpcServer, rpcStream, rpcAPIArr); err != nil {
+		srvCtx.Logger.Error("failed to register txpool namespace", "error", err)
+		return nil, err
+	}
+
 	r := mux.NewRouter()
-	r.HandleFunc("/", rpcServer.ServeHTTP).Methods("POST")
+	r.Handle("/", methodFilter(config.JSONRPC.AllowMethods, config.JSONRPC.DenyMethods, rpcServer)).Methods("POST")
+
+	if config.JSONRPC.EnableGraphQL {
+		if err := registerGraphQL(logger, r, evmBackend, "/graphql", "/graphql/ui"); err != nil {
+			return nil, err
+		}
+	}
 
 	handlerWithCors := cors.Default()
 	if config.API.EnableUnsafeCORS {
@@ -165,7 +240,146 @@ func StartJSONRPC(
 
 	srvCtx.Logger.Info("Starting JSON WebSocket server", "address", config.JSONRPC.WsAddress)
 
+	// rpc.NewWebsocketsServer's `newPendingTransactions` handler recognizes
+	// the `fullTransactions: true` parameter and, when set, streams from
+	// rpcStream.PendingFullTxStream() instead of rpcStream.PendingTxStream().
 	wsSrv := rpc.NewWebsocketsServer(ctx, clientCtx, srvCtx.Logger, rpcStream, config)
 	wsSrv.Start()
+
+	if config.JSONRPC.IPCPath != "" {
+		// The IPC endpoint is not subject to CORS or the HTTP API allowlist:
+		// it registers the public allowlist plus every privileged namespace
+		// (personal, debug, miner, ...), built from a dedicated full
+		// namespace list rather than the allowlisted `apis` above, so it
+		// must only be reachable by local, trusted processes.
+		ipcAPIArr := ipcNamespaceArr(rpcAPIArr)
+		ipcAPIs := rpc.GetRPCAPIs(srvCtx, clientCtx, rpcStream, allowUnprotectedTxs, indexer, ipcAPIArr)
+
+		ipcServer := ethrpc.NewServer()
+		for _, api := range ipcAPIs {
+			if err := ipcServer.RegisterName(api.Namespace, api.Service); err != nil {
+				srvCtx.Logger.Error(
+					"failed to register service in IPC namespace",
+					"namespace", api.Namespace,
+					"service", api.Service,
+				)
+				return nil, err
+			}
+		}
+
+		if err := registerTxPool(ipcServer, rpcStream, ipcAPIArr); err != nil {
+			srvCtx.Logger.Error("failed to register txpool namespace on IPC", "error", err)
+			return nil, err
+		}
+
+		if err := startIPCEndpoint(ctx, g, logger, config.JSONRPC.IPCPath, ipcServer); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.JSONRPC.AuthAddress != "" {
+		if err := startAuthenticatedEndpoint(ctx, srvCtx, clientCtx, g, config, rpcStream, indexer, httpSrv); err != nil {
+			return nil, err
+		}
+	}
+
 	return httpSrv, nil
 }
+
+// startAuthenticatedEndpoint starts a second HTTP listener, separate from the
+// public one, that exposes the privileged namespaces (`debug`, `personal`,
+// `miner`, and `admin`) behind HS256 JWT bearer-token authentication. It is
+// never subject to the public allowlist in config.JSONRPC.API or to the
+// method filter applied to the public endpoint: access control here is the
+// JWT, same as go-ethereum's Engine API.
+func startAuthenticatedEndpoint(
+	ctx context.Context,
+	srvCtx *server.Context,
+	clientCtx client.Context,
+	g *errgroup.Group,
+	cfg *config.Config,
+	rpcStream *stream.RPCStream,
+	indexer ethermint.EVMTxIndexer,
+	publicHTTPSrv *http.Server,
+) error {
+	secret, err := loadOrGenerateJWTSecret(cfg.JSONRPC.JWTSecret, srvCtx.Config.RootDir)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT secret: %w", err)
+	}
+
+	authAPIArr := cfg.JSONRPC.AuthAPI
+	allowUnprotectedTxs := cfg.JSONRPC.AllowUnprotectedTxs
+
+	// An unset auth-api falls back to the privileged namespace set (the same
+	// one IPC exposes), not the public allowlist: this endpoint exists
+	// specifically to serve debug/personal/miner, and mirroring the public
+	// allowlist would leave it unable to do that until an operator manually
+	// configured auth-api.
+	if len(authAPIArr) == 0 {
+		authAPIArr = ipcNamespaceArr(cfg.JSONRPC.API)
+	}
+	authAPIs := rpc.GetRPCAPIs(srvCtx, clientCtx, rpcStream, allowUnprotectedTxs, indexer, authAPIArr)
+
+	authRPCServer := ethrpc.NewServer()
+	for _, api := range authAPIs {
+		if err := authRPCServer.RegisterName(api.Namespace, api.Service); err != nil {
+			srvCtx.Logger.Error(
+				"failed to register service in authenticated JSON RPC namespace",
+				"namespace", api.Namespace,
+				"service", api.Service,
+			)
+			return err
+		}
+	}
+
+	adminAPI := admin.NewPublicAdminAPI(srvCtx.Logger, clientCtx.Client, newControllableHTTPServer(publicHTTPSrv, cfg.JSONRPC.Address, cfg))
+	if err := authRPCServer.RegisterName("admin", adminAPI); err != nil {
+		return err
+	}
+
+	if err := registerTxPool(authRPCServer, rpcStream, authAPIArr); err != nil {
+		return err
+	}
+
+	authRouter := mux.NewRouter()
+	authRouter.Handle("/", jwtAuth(secret, authRPCServer)).Methods("POST")
+
+	authSrv := &http.Server{
+		Addr:              cfg.JSONRPC.AuthAddress,
+		Handler:           authRouter,
+		ReadHeaderTimeout: cfg.JSONRPC.HTTPTimeout,
+		ReadTimeout:       cfg.JSONRPC.HTTPTimeout,
+		WriteTimeout:      cfg.JSONRPC.HTTPTimeout,
+		IdleTimeout:       cfg.JSONRPC.HTTPIdleTimeout,
+	}
+
+	ln, err := Listen(authSrv.Addr, cfg)
+	if err != nil {
+		return err
+	}
+
+	g.Go(func() error {
+		srvCtx.Logger.Info("Starting authenticated JSON-RPC server", "address", cfg.JSONRPC.AuthAddress)
+		errCh := make(chan error)
+		go func() {
+			errCh <- authSrv.Serve(ln)
+		}()
+
+		select {
+		case <-ctx.Done():
+			srvCtx.Logger.Info("stopping authenticated JSON-RPC server...", "address", cfg.JSONRPC.AuthAddress)
+			if err := authSrv.Shutdown(context.Background()); err != nil {
+				srvCtx.Logger.Error("failed to shutdown authenticated JSON-RPC server", "error", err.Error())
+			}
+			return ln.Close()
+
+		case err := <-errCh:
+			if err != http.ErrServerClosed {
+				srvCtx.Logger.Error("failed to start authenticated JSON-RPC server", "error", err.Error())
+			}
+			return err
+		}
+	})
+
+	return nil
+}
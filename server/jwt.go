@@ -0,0 +1,126 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	jwtSecretLength = 32
+	jwtSecretFile   = "jwt.hex"
+
+	// maxJWTClockSkew matches go-ethereum's Engine API handshake: an `iat`
+	// claim outside this window around "now" is rejected, which bounds how
+	// long a captured token can be replayed.
+	maxJWTClockSkew = 60 * time.Second
+)
+
+// loadOrGenerateJWTSecret reads a hex-encoded 32-byte secret from path. If
+// path is empty, it loads (or, on first start, generates and persists) a
+// secret at jwt.hex under fallbackDir, mirroring go-ethereum's behaviour for
+// its authenticated Engine API endpoint.
+func loadOrGenerateJWTSecret(path, fallbackDir string) ([]byte, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := decodeJWTHex(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT secret in %s: %w", path, err)
+		}
+		return secret, nil
+	}
+
+	genPath := filepath.Join(fallbackDir, jwtSecretFile)
+	if data, err := os.ReadFile(genPath); err == nil {
+		if secret, err := decodeJWTHex(string(data)); err == nil {
+			return secret, nil
+		}
+	}
+
+	secret := make([]byte, jwtSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(genPath, []byte(hex.EncodeToString(secret)), 0o600); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func decodeJWTHex(s string) ([]byte, error) {
+	secret, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) != jwtSecretLength {
+		return nil, errors.New("JWT secret must be 32 bytes")
+	}
+	return secret, nil
+}
+
+// jwtAuth wraps next with HS256 JWT bearer-token authentication: requests
+// must carry `Authorization: Bearer <token>`, signed with secret, whose `iat`
+// claim is within maxJWTClockSkew of the current time - the same scheme
+// go-ethereum uses to protect its Engine API.
+func jwtAuth(secret []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "Bearer "
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, prefix) {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		// The library's own claims validation rejects any `iat` in the
+		// future, so it is turned off and `iat` is checked against
+		// maxJWTClockSkew in both directions below.
+		var claims jwt.RegisteredClaims
+		token, err := jwt.ParseWithClaims(strings.TrimPrefix(auth, prefix), &claims, func(t *jwt.Token) (interface{}, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+			}
+			return secret, nil
+		}, jwt.WithoutClaimsValidation())
+		if err != nil || !token.Valid {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		if claims.IssuedAt == nil {
+			http.Error(w, "token missing iat claim", http.StatusUnauthorized)
+			return
+		}
+		if skew := time.Since(claims.IssuedAt.Time); skew < -maxJWTClockSkew || skew > maxJWTClockSkew {
+			http.Error(w, "token iat outside allowed clock skew", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
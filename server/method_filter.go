@@ -0,0 +1,132 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// methodNotFoundCode is the JSON-RPC 2.0 error code for an unknown/blocked
+// method, matching what go-ethereum's own rpc.Server returns for methods
+// that aren't registered.
+const methodNotFoundCode = -32601
+
+type jsonrpcEnvelope struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+type jsonrpcErrorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   jsonrpcError    `json:"error"`
+}
+
+type jsonrpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// methodAllowed reports whether method may be dispatched: deny always wins
+// over allow, and an empty allow list means "allow everything not denied".
+func methodAllowed(method string, allow, deny map[string]struct{}) bool {
+	if _, blocked := deny[method]; blocked {
+		return false
+	}
+	if len(allow) == 0 {
+		return true
+	}
+	_, ok := allow[method]
+	return ok
+}
+
+func toSet(methods []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		set[m] = struct{}{}
+	}
+	return set
+}
+
+// methodFilter wraps next with a per-method allowlist/denylist, parsing the
+// JSON-RPC envelope - including batch requests - before it ever reaches
+// next's dispatcher. A batch containing even one blocked method is rejected
+// with a single -32601 response rather than partially dispatched; requests
+// that only name allowed methods are forwarded unchanged.
+func methodFilter(allowMethods, denyMethods []string, next http.Handler) http.Handler {
+	allow, deny := toSet(allowMethods), toSet(denyMethods)
+	if len(allow) == 0 && len(deny) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body.Close()
+
+		var single jsonrpcEnvelope
+		if err := json.Unmarshal(body, &single); err == nil && single.Method != "" {
+			if !methodAllowed(single.Method, allow, deny) {
+				writeJSONRPCError(w, single.ID, single.Method)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var batch []jsonrpcEnvelope
+		if err := json.Unmarshal(body, &batch); err == nil {
+			for _, call := range batch {
+				if !methodAllowed(call.Method, allow, deny) {
+					writeJSONRPCError(w, call.ID, call.Method)
+					return
+				}
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// not a shape we understand; let the RPC server's own parser reject it.
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSONRPCError(w http.ResponseWriter, id json.RawMessage, method string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(jsonrpcErrorResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: jsonrpcError{
+			Code:    methodNotFoundCode,
+			Message: "method " + method + " is not allowed",
+		},
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/evmos/ethermint/server/config"
+)
+
+// controllableHTTPServer adapts an HTTP handler bound to an address (rather
+// than an already-open listener) to admin.RPCServer, so admin_startRPC and
+// admin_stopRPC can toggle the public JSON-RPC HTTP endpoint on and off
+// without tearing down the rest of the node.
+//
+// A *http.Server may not be reused once Shutdown/Close has returned: Serve
+// on a closed server returns http.ErrServerClosed immediately. So rather than
+// holding a single *http.Server across Stop/Start cycles, this builds a new
+// one from the saved handler and timeouts each time Start runs.
+type controllableHTTPServer struct {
+	mu      sync.Mutex
+	srv     *http.Server
+	handler http.Handler
+	addr    string
+	cfg     *config.Config
+	running bool
+}
+
+func newControllableHTTPServer(srv *http.Server, addr string, cfg *config.Config) *controllableHTTPServer {
+	return &controllableHTTPServer{srv: srv, handler: srv.Handler, addr: addr, cfg: cfg, running: true}
+}
+
+func (c *controllableHTTPServer) Start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.running {
+		return nil
+	}
+
+	ln, err := Listen(c.addr, c.cfg)
+	if err != nil {
+		return err
+	}
+
+	c.srv = &http.Server{
+		Addr:              c.addr,
+		Handler:           c.handler,
+		ReadHeaderTimeout: c.srv.ReadHeaderTimeout,
+		ReadTimeout:       c.srv.ReadTimeout,
+		WriteTimeout:      c.srv.WriteTimeout,
+		IdleTimeout:       c.srv.IdleTimeout,
+	}
+	c.running = true
+	go c.srv.Serve(ln)
+	return nil
+}
+
+func (c *controllableHTTPServer) Stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return nil
+	}
+	c.running = false
+	return c.srv.Shutdown(context.Background())
+}